@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"database/sql"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// NetworkPolicyAttachment lets attachment of a network policy to the account
+// and/or a set of users be managed independently of the network policy
+// definition itself, for cases where a single shared policy is attached from
+// many places.
+func NetworkPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateNetworkPolicyAttachment,
+		Read:   ReadNetworkPolicyAttachment,
+		Update: UpdateNetworkPolicyAttachment,
+		Delete: DeleteNetworkPolicyAttachment,
+
+		Schema: map[string]*schema.Schema{
+			"network_policy_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Specifies the identifier for the network policy to attach.",
+				ValidateFunc: func(val interface{}, key string) ([]string, []error) {
+					return snowflake.ValidateIdentifier(val)
+				},
+			},
+			"set_for_account": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Specifies whether the network policy should be applied at the account level.",
+			},
+			"users": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Specifies which users the network policy should be applied to.",
+			},
+		},
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func CreateNetworkPolicyAttachment(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	policyName := data.Get("network_policy_name").(string)
+	builder := snowflake.NetworkPolicy(policyName)
+
+	if data.Get("set_for_account").(bool) {
+		if err := DBExec(db, builder.SetOnAccount()); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range expandStringList(data.Get("users").([]interface{})) {
+		if err := DBExec(db, builder.SetOnUser(user)); err != nil {
+			return err
+		}
+	}
+
+	data.SetId(policyName)
+	return ReadNetworkPolicyAttachment(data, meta)
+}
+
+// ReadNetworkPolicyAttachment queries Snowflake's NETWORK_POLICY parameter
+// for the account and for every user already tracked in state, so that a
+// policy being detached (or another one attached) out-of-band shows up as
+// drift instead of this resource just echoing its own ID back into state.
+func ReadNetworkPolicyAttachment(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	policyName := data.Id()
+
+	if err := data.Set("network_policy_name", policyName); err != nil {
+		return err
+	}
+
+	setForAccount, attachedUsers, err := readNetworkPolicyAttachment(db, policyName, expandStringList(data.Get("users").([]interface{})))
+	if err != nil {
+		return err
+	}
+	if err := data.Set("set_for_account", setForAccount); err != nil {
+		return err
+	}
+	return data.Set("users", attachedUsers)
+}
+
+func UpdateNetworkPolicyAttachment(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	policyName := data.Get("network_policy_name").(string)
+	builder := snowflake.NetworkPolicy(policyName)
+
+	if data.HasChange("set_for_account") {
+		if data.Get("set_for_account").(bool) {
+			if err := DBExec(db, builder.SetOnAccount()); err != nil {
+				return err
+			}
+		} else if err := DBExec(db, builder.UnsetOnAccount()); err != nil {
+			return err
+		}
+	}
+
+	if data.HasChange("users") {
+		oldUsers, newUsers := data.GetChange("users")
+		remove := expandStringList(oldUsers.([]interface{}))
+		add := expandStringList(newUsers.([]interface{}))
+
+		for _, user := range remove {
+			if !contains(add, user) {
+				if err := DBExec(db, builder.UnsetOnUser(user)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, user := range add {
+			if !contains(remove, user) {
+				if err := DBExec(db, builder.SetOnUser(user)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return ReadNetworkPolicyAttachment(data, meta)
+}
+
+func DeleteNetworkPolicyAttachment(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	policyName := data.Get("network_policy_name").(string)
+	builder := snowflake.NetworkPolicy(policyName)
+
+	if data.Get("set_for_account").(bool) {
+		if err := DBExec(db, builder.UnsetOnAccount()); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range expandStringList(data.Get("users").([]interface{})) {
+		if err := DBExec(db, builder.UnsetOnUser(user)); err != nil {
+			return err
+		}
+	}
+
+	data.SetId("")
+	return nil
+}
@@ -7,10 +7,22 @@ import (
 	"strings"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake/grantcache"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/jmoiron/sqlx"
 )
 
+// grantCache memoizes SHOW GRANTS rows across every snowflake_role_grants
+// and snowflake_role_template_binding call in a single provider session,
+// keyed by the *sql.DB passed as meta. See grantcache.Disabled for the
+// SNOWFLAKE_DISABLE_GRANT_CACHE escape hatch.
+var grantCache = grantcache.New()
+
+// ofRoleKind namespaces grantCache entries populated by readGrants's
+// `SHOW GRANTS OF ROLE` rows from the `SHOW GRANTS TO ROLE` rows cached by
+// role_template_binding.go, so both readers can share grantCache.
+const ofRoleKind = "of_role"
+
 func RoleGrants() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateRoleGrants,
@@ -18,6 +30,19 @@ func RoleGrants() *schema.Resource {
 		Delete: DeleteRoleGrants,
 		Update: UpdateRoleGrants,
 
+		// SchemaVersion 1 added with_admin_option by turning roles/users
+		// from a list of plain name strings into a list of
+		// {name, with_admin_option} objects; see roleGrantsV0 and
+		// upgradeRoleGrantsV0.
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    roleGrantsV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeRoleGrantsV0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"role_name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -30,15 +55,43 @@ func RoleGrants() *schema.Resource {
 			},
 			"roles": &schema.Schema{
 				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Grants role to this specified role.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the role to grant this role to.",
+						},
+						"with_admin_option": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Grants the role with WITH ADMIN OPTION, allowing the granted role to in turn re-grant it to other roles/users.",
+						},
+					},
+				},
 			},
 			"users": &schema.Schema{
 				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Grants role to this specified user.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the user to grant this role to.",
+						},
+						"with_admin_option": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Grants the role with WITH ADMIN OPTION, allowing the granted user to in turn re-grant it to other roles/users.",
+						},
+					},
+				},
 			},
 		},
 
@@ -48,25 +101,107 @@ func RoleGrants() *schema.Resource {
 	}
 }
 
+// roleGrantsV0 is the pre-with_admin_option schema: roles/users were a
+// plain list of grantee name strings. It exists only so StateUpgraders can
+// decode SchemaVersion 0 state; RoleGrants() itself is the current schema.
+func roleGrantsV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"role_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"roles": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"users": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// upgradeRoleGrantsV0 rewrites SchemaVersion 0 state's roles/users
+// (a list of grantee name strings) into the SchemaVersion 1 shape (a list
+// of {name, with_admin_option} objects), so existing
+// snowflake_role_grants resources in state don't force a recreate when
+// upgrading to a provider version with WITH ADMIN OPTION support.
+// with_admin_option defaults to false: SchemaVersion 0 predates the
+// option, so every grant it recorded was made without it.
+func upgradeRoleGrantsV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState["roles"] = upgradeGranteeListV0(rawState["roles"])
+	rawState["users"] = upgradeGranteeListV0(rawState["users"])
+	return rawState, nil
+}
+
+func upgradeGranteeListV0(raw interface{}) []interface{} {
+	names, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	upgraded := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		upgraded = append(upgraded, map[string]interface{}{
+			"name":              name,
+			"with_admin_option": false,
+		})
+	}
+	return upgraded
+}
+
+// grantee represents a single principal (role or user) that a role is
+// granted to, along with whether that grant carries WITH ADMIN OPTION.
+type grantee struct {
+	name            string
+	withAdminOption bool
+}
+
+func expandGrantees(in []interface{}) []grantee {
+	grantees := make([]grantee, 0, len(in))
+	for _, raw := range in {
+		m := raw.(map[string]interface{})
+		grantees = append(grantees, grantee{
+			name:            m["name"].(string),
+			withAdminOption: m["with_admin_option"].(bool),
+		})
+	}
+	return grantees
+}
+
+func flattenGrantees(in []grantee) []interface{} {
+	out := make([]interface{}, 0, len(in))
+	for _, g := range in {
+		out = append(out, map[string]interface{}{
+			"name":              g.name,
+			"with_admin_option": g.withAdminOption,
+		})
+	}
+	return out
+}
+
 func CreateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	db := meta.(*sql.DB)
 	roleName := data.Get("role_name").(string)
-	roles := expandStringList(data.Get("roles").([]interface{}))
-	users := expandStringList(data.Get("users").([]interface{}))
+	roles := expandGrantees(data.Get("roles").([]interface{}))
+	users := expandGrantees(data.Get("users").([]interface{}))
 
 	if len(roles) == 0 && len(users) == 0 {
 		return fmt.Errorf("no users or roles specified for role grants")
 	}
 
 	for _, role := range roles {
-		err := grantRoleToRole(db, roleName, role)
+		err := grantRoleToRole(db, roleName, role.name, role.withAdminOption)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, user := range users {
-		err := grantRoleToUser(db, roleName, user)
+		err := grantRoleToUser(db, roleName, user.name, user.withAdminOption)
 		if err != nil {
 			return err
 		}
@@ -75,16 +210,22 @@ func CreateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	return ReadRoleGrants(data, meta)
 }
 
-func grantRoleToRole(db *sql.DB, role1, role2 string) error {
+func grantRoleToRole(db *sql.DB, role1, role2 string, withAdminOption bool) error {
 	g := snowflake.RoleGrant(role1)
-	err := DBExec(db, g.Role(role2).Grant())
-	return err
+	if err := DBExec(db, g.Role(role2).WithAdminOption(withAdminOption).Grant()); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role1)
+	return nil
 }
 
-func grantRoleToUser(db *sql.DB, role1, user string) error {
+func grantRoleToUser(db *sql.DB, role1, user string, withAdminOption bool) error {
 	g := snowflake.RoleGrant(role1)
-	err := DBExec(db, g.User(user).Grant())
-	return err
+	if err := DBExec(db, g.User(user).WithAdminOption(withAdminOption).Grant()); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role1)
+	return nil
 }
 
 type roleGrant struct {
@@ -92,15 +233,20 @@ type roleGrant struct {
 	Role        sql.NullString `db:"role"`
 	GrantedTo   sql.NullString `db:"granted_to"`
 	GranteeName sql.NullString `db:"grantee_name"`
-	Grantedby   sql.NullString `db:"granted_by"`
+	Grantedby   sql.NullString `db:"granted_by_role"`
+	GrantOption sql.NullString `db:"grant_option"`
+}
+
+func (g *roleGrant) hasAdminOption() bool {
+	return strings.EqualFold(g.GrantOption.String, "true")
 }
 
 func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	db := meta.(*sql.DB)
 	roleName := data.Id()
 
-	roles := make([]string, 0)
-	users := make([]string, 0)
+	roles := make([]grantee, 0)
+	users := make([]grantee, 0)
 
 	grants, err := readGrants(db, roleName)
 	if err != nil {
@@ -108,11 +254,12 @@ func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	}
 
 	for _, grant := range grants {
+		g := grantee{name: grant.GranteeName.String, withAdminOption: grant.hasAdminOption()}
 		switch grant.GrantedTo.String {
 		case "ROLE":
-			roles = append(roles, grant.GranteeName.String)
+			roles = append(roles, g)
 		case "USER":
-			users = append(users, grant.GranteeName.String)
+			users = append(users, g)
 		default:
 			return fmt.Errorf("unknown grant type %s", grant.GrantedTo.String)
 		}
@@ -122,11 +269,11 @@ func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	err = data.Set("roles", roles)
+	err = data.Set("roles", flattenGrantees(roles))
 	if err != nil {
 		return err
 	}
-	err = data.Set("users", users)
+	err = data.Set("users", flattenGrantees(users))
 	if err != nil {
 		return err
 	}
@@ -134,7 +281,23 @@ func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// readGrants serves roleName's `SHOW GRANTS OF ROLE` rows from grantCache,
+// falling back to fetchRoleGrants on a miss (first read of this role in the
+// session, or a read just after Invalidate).
 func readGrants(db *sql.DB, roleName string) ([]*roleGrant, error) {
+	rows, err := grantCache.Get(db, ofRoleKind, roleName, func(roleName string) (interface{}, error) {
+		return fetchRoleGrants(db, roleName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows.([]*roleGrant), nil
+}
+
+// fetchRoleGrants issues a single `SHOW GRANTS OF ROLE` and scans every row
+// it returns; grantCache.Get is the only caller and pagination is handled
+// by sqlx's row cursor, not by this function.
+func fetchRoleGrants(db *sql.DB, roleName string) ([]*roleGrant, error) {
 	sdb := sqlx.NewDb(db, "snowflake")
 
 	stmt := fmt.Sprintf(`SHOW GRANTS OF ROLE "%s"`, roleName)
@@ -171,18 +334,18 @@ func DeleteRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	db := meta.(*sql.DB)
 	roleName := data.Get("role_name").(string)
 
-	roles := expandStringList(data.Get("roles").([]interface{}))
-	users := expandStringList(data.Get("users").([]interface{}))
+	roles := expandGrantees(data.Get("roles").([]interface{}))
+	users := expandGrantees(data.Get("users").([]interface{}))
 
 	for _, role := range roles {
-		err := revokeRoleFromRole(db, roleName, role)
+		err := revokeRoleFromRole(db, roleName, role.name)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, user := range users {
-		err := revokeRoleFromUser(db, roleName, user)
+		err := revokeRoleFromUser(db, roleName, user.name)
 		if err != nil {
 			return err
 		}
@@ -194,14 +357,20 @@ func DeleteRoleGrants(data *schema.ResourceData, meta interface{}) error {
 
 func revokeRoleFromRole(db *sql.DB, role1, role2 string) error {
 	rg := snowflake.RoleGrant(role1).Role(role2)
-	err := DBExec(db, rg.Revoke())
-	return err
+	if err := DBExec(db, rg.Revoke()); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role1)
+	return nil
 }
 
 func revokeRoleFromUser(db *sql.DB, role1, user string) error {
 	rg := snowflake.RoleGrant(role1).User(user)
-	err := DBExec(db, rg.Revoke())
-	return err
+	if err := DBExec(db, rg.Revoke()); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role1)
+	return nil
 }
 
 func UpdateRoleGrants(data *schema.ResourceData, meta interface{}) error {
@@ -213,40 +382,59 @@ func UpdateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	existingRoleGrants := schema.NewSet(schema.HashString, nil)
-	existingUserGrants := schema.NewSet(schema.HashString, nil)
+	existingRoleGrants := map[string]bool{}
+	existingUserGrants := map[string]bool{}
 
 	for _, existingGrant := range existingGrants {
 		switch grantedTo := strings.ToUpper(existingGrant.GrantedTo.String); grantedTo {
 		case "USER":
-			existingUserGrants.Add(existingGrant.GranteeName.String)
+			existingUserGrants[existingGrant.GranteeName.String] = existingGrant.hasAdminOption()
 		case "ROLE":
-			existingRoleGrants.Add(existingGrant.GranteeName.String)
+			existingRoleGrants[existingGrant.GranteeName.String] = existingGrant.hasAdminOption()
 		default:
 			return fmt.Errorf("Role granted_to unrecognized type(%s)", grantedTo)
 		}
 	}
 
-	type funcRoleGrant func(db *sql.DB, resource string, target string) error
-	x := func(resource string, grant funcRoleGrant, revoke funcRoleGrant, existingDataSet *schema.Set) error {
+	type funcGrantRole func(db *sql.DB, resource string, target string, withAdminOption bool) error
+	type funcRevokeRole func(db *sql.DB, resource string, target string) error
+	// x reconciles the desired set of grantees for `resource` ("roles" or
+	// "users") against the grantees observed on the role in Snowflake,
+	// revoking/granting only the principals that actually changed so that
+	// toggling with_admin_option on one grantee doesn't touch the others.
+	x := func(resource string, grant funcGrantRole, revoke funcRevokeRole, existingGrantees map[string]bool) error {
 		iNewData := data.Get(resource)
 		log.Printf("[DEBUG] iNewData %v", iNewData)
-		newData := schema.NewSet(schema.HashString, iNewData.([]interface{}))
-		log.Printf("[DEBUG] newData %v", newData.List())
-
-		remove := expandStringList(existingDataSet.Difference(newData).List())
-		add := expandStringList(newData.Difference(existingDataSet).List())
+		newGrantees := expandGrantees(iNewData.([]interface{}))
+		newData := map[string]bool{}
+		for _, g := range newGrantees {
+			newData[g.name] = g.withAdminOption
+		}
+		log.Printf("[DEBUG] newData %v", newData)
 
-		for _, user := range remove {
-			err := revoke(db, roleName, user)
-			if err != nil {
-				return err
+		for name := range existingGrantees {
+			if _, ok := newData[name]; !ok {
+				if err := revoke(db, roleName, name); err != nil {
+					return err
+				}
 			}
 		}
-		for _, user := range add {
-			err := grant(db, roleName, user)
-			if err != nil {
-				return err
+
+		for name, withAdminOption := range newData {
+			existingAdminOption, ok := existingGrantees[name]
+			if !ok {
+				if err := grant(db, roleName, name, withAdminOption); err != nil {
+					return err
+				}
+				continue
+			}
+			if existingAdminOption != withAdminOption {
+				if err := revoke(db, roleName, name); err != nil {
+					return err
+				}
+				if err := grant(db, roleName, name, withAdminOption); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
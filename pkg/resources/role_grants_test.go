@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeRoleGrantsV0(t *testing.T) {
+	r := require.New(t)
+
+	rawState := map[string]interface{}{
+		"role_name": "analyst",
+		"roles":     []interface{}{"admin"},
+		"users":     []interface{}{"alice", "bob"},
+	}
+
+	upgraded, err := upgradeRoleGrantsV0(rawState, nil)
+	r.NoError(err)
+	r.Equal([]interface{}{
+		map[string]interface{}{"name": "admin", "with_admin_option": false},
+	}, upgraded["roles"])
+	r.Equal([]interface{}{
+		map[string]interface{}{"name": "alice", "with_admin_option": false},
+		map[string]interface{}{"name": "bob", "with_admin_option": false},
+	}, upgraded["users"])
+}
+
+func TestUpgradeRoleGrantsV0EmptyLists(t *testing.T) {
+	r := require.New(t)
+
+	rawState := map[string]interface{}{
+		"role_name": "analyst",
+	}
+
+	upgraded, err := upgradeRoleGrantsV0(rawState, nil)
+	r.NoError(err)
+	r.Nil(upgraded["roles"])
+	r.Nil(upgraded["users"])
+}
@@ -0,0 +1,211 @@
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func warehouseTemplateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the warehouse to grant access on.",
+			},
+			"usage": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants USAGE on the warehouse.",
+			},
+			"operate": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants OPERATE on the warehouse.",
+			},
+		},
+	}
+}
+
+func databaseTemplateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the database to grant access on.",
+			},
+			"usage": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants USAGE on the database.",
+			},
+			"monitor": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants MONITOR on the database.",
+			},
+		},
+	}
+}
+
+func schemaTemplateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `Qualified name of the schema ("<database>.<schema>") to grant access on.`,
+			},
+			"usage": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants USAGE on the schema.",
+			},
+			"create_table": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants CREATE TABLE on the schema.",
+			},
+			"create_view": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants CREATE VIEW on the schema.",
+			},
+		},
+	}
+}
+
+func tableFutureTemplateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"schema": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `Qualified name of the schema ("<database>.<schema>") whose future tables are granted access.`,
+			},
+			"select": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants SELECT on future tables created in the schema.",
+			},
+			"insert": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants INSERT on future tables created in the schema.",
+			},
+			"update": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants UPDATE on future tables created in the schema.",
+			},
+			"delete": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Grants DELETE on future tables created in the schema.",
+			},
+		},
+	}
+}
+
+// templateGrantSchema is shared by RoleTemplate and RoleTemplateBinding: the
+// template declares the bundle, the binding fans the same shape out to a role.
+func templateGrantSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"warehouses": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Warehouse-scoped grants included in this template.",
+			Elem:        warehouseTemplateResource(),
+		},
+		"databases": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Database-scoped grants included in this template.",
+			Elem:        databaseTemplateResource(),
+		},
+		"schemas": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Schema-scoped grants included in this template.",
+			Elem:        schemaTemplateResource(),
+		},
+		"tables_future": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Future-table grants included in this template.",
+			Elem:        tableFutureTemplateResource(),
+		},
+	}
+}
+
+// RoleTemplate declares a reusable, named bundle of resource-scoped grants
+// (warehouses, databases, schemas, future table grants) that can be fanned
+// out to many roles via one or more snowflake_role_template_binding
+// resources. It has no Snowflake-side object of its own; applying it only
+// records the bundle definition in state. Bindings do not read this
+// resource: each binding grants what its own warehouses/databases/schemas/
+// tables_future blocks list and diffs against its own prior state.
+func RoleTemplate() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of this role template, referenced by snowflake_role_template_binding resources.",
+		},
+	}
+	for key, value := range templateGrantSchema() {
+		s[key] = value
+	}
+
+	return &schema.Resource{
+		Create: CreateRoleTemplate,
+		Read:   ReadRoleTemplate,
+		Update: UpdateRoleTemplate,
+		Delete: DeleteRoleTemplate,
+
+		Schema: s,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// CreateRoleTemplate records the template definition in state. There is
+// nothing to create in Snowflake: grants only take effect through a
+// snowflake_role_template_binding, which applies its own grant blocks.
+func CreateRoleTemplate(data *schema.ResourceData, meta interface{}) error {
+	data.SetId(data.Get("name").(string))
+	return nil
+}
+
+// ReadRoleTemplate is a no-op: the template has no remote state to drift
+// against, it is purely a local composition of grants.
+func ReadRoleTemplate(data *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// UpdateRoleTemplate is a no-op for the same reason as ReadRoleTemplate.
+// Changing a template changes no grants: snowflake_role_template_binding
+// resources do not read it, and only change grants when their own grant
+// blocks change.
+func UpdateRoleTemplate(data *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func DeleteRoleTemplate(data *schema.ResourceData, meta interface{}) error {
+	data.SetId("")
+	return nil
+}
@@ -0,0 +1,517 @@
+package resources
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/jmoiron/sqlx"
+)
+
+// RoleTemplateBinding binds a bundle of grants to a single role, fanning each
+// (object, privilege) pair out into the existing warehouse/database/schema/
+// future grant SQL builders instead of requiring one snowflake_*_grant
+// resource per privilege. The grants applied are the ones listed in this
+// resource's own warehouses/databases/schemas/tables_future blocks; the
+// snowflake_role_template named by template_name is not read.
+func RoleTemplateBinding() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"template_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the snowflake_role_template this binding belongs to; only used to key the binding in the resource ID. The grants applied come from this resource's own grant blocks, not from the template.",
+		},
+		"role_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the role the template's grants are bound to.",
+			ValidateFunc: func(val interface{}, key string) ([]string, []error) {
+				return snowflake.ValidateIdentifier(val)
+			},
+		},
+		"strict": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "When true, each privilege attribute of the template (e.g. usage, operate, select) is read back from the grants Snowflake reports for the role, so an out-of-band grant or revoke of one of those privileges shows up as drift and is reconciled on the next apply. Privileges the template has no attribute for are not checked. When false (the default), out-of-band grants on those objects are tolerated.",
+		},
+	}
+	for key, value := range templateGrantSchema() {
+		s[key] = value
+	}
+
+	return &schema.Resource{
+		Create: CreateRoleTemplateBinding,
+		Read:   ReadRoleTemplateBinding,
+		Update: UpdateRoleTemplateBinding,
+		Delete: DeleteRoleTemplateBinding,
+
+		Schema: s,
+
+		Importer: &schema.ResourceImporter{
+			State: importRoleTemplateBinding,
+		},
+	}
+}
+
+// importRoleTemplateBinding splits the "<template_name>|<role_name>" ID back
+// into its fields so `terraform import` doesn't require them to be guessed.
+func importRoleTemplateBinding(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	templateName, roleName, err := parseTemplateBindingID(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Set("template_name", templateName); err != nil {
+		return nil, err
+	}
+	if err := data.Set("role_name", roleName); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+// templateGrant is a single (object, privilege) pair fanned out of a
+// template's warehouses/databases/schemas/tables_future blocks.
+type templateGrant struct {
+	objectType string // WAREHOUSE, DATABASE, SCHEMA, TABLE_FUTURE
+	target     string // object name, or "<database>.<schema>" for SCHEMA/TABLE_FUTURE
+	privilege  string
+}
+
+func (g templateGrant) key() string {
+	return strings.Join([]string{g.objectType, g.target, g.privilege}, "|")
+}
+
+// templateCategories are the ResourceData keys shared by RoleTemplate and
+// RoleTemplateBinding that each fan out into templateGrants.
+var templateCategories = []string{"warehouses", "databases", "schemas", "tables_future"}
+
+func expandTemplateGrants(data *schema.ResourceData) []templateGrant {
+	grants := make([]templateGrant, 0)
+	for _, category := range templateCategories {
+		grants = append(grants, expandTemplateGrantsFromList(data.Get(category).([]interface{}), category)...)
+	}
+	return grants
+}
+
+func splitQualifiedName(name string) (string, string, error) {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf(`expected a qualified name in the form "<database>.<schema>", got %q`, name)
+	}
+	return parts[0], parts[1], nil
+}
+
+func execTemplateGrant(db *sql.DB, role string, g templateGrant) error {
+	switch g.objectType {
+	case "WAREHOUSE":
+		return DBExec(db, snowflake.WarehouseGrant(g.target).Role(role).Grant(g.privilege, false))
+	case "DATABASE":
+		return DBExec(db, snowflake.DatabaseGrant(g.target).Role(role).Grant(g.privilege, false))
+	case "SCHEMA":
+		dbName, schemaName, err := splitQualifiedName(g.target)
+		if err != nil {
+			return err
+		}
+		return DBExec(db, snowflake.SchemaGrant(dbName, schemaName).Role(role).Grant(g.privilege, false))
+	case "TABLE_FUTURE":
+		dbName, schemaName, err := splitQualifiedName(g.target)
+		if err != nil {
+			return err
+		}
+		return DBExec(db, snowflake.FutureGrant(dbName, schemaName, "TABLES").Role(role).Grant(g.privilege))
+	default:
+		return fmt.Errorf("unknown role template grant object type %s", g.objectType)
+	}
+}
+
+func execTemplateRevoke(db *sql.DB, role string, g templateGrant) error {
+	switch g.objectType {
+	case "WAREHOUSE":
+		return DBExec(db, snowflake.WarehouseGrant(g.target).Role(role).Revoke(g.privilege))
+	case "DATABASE":
+		return DBExec(db, snowflake.DatabaseGrant(g.target).Role(role).Revoke(g.privilege))
+	case "SCHEMA":
+		dbName, schemaName, err := splitQualifiedName(g.target)
+		if err != nil {
+			return err
+		}
+		return DBExec(db, snowflake.SchemaGrant(dbName, schemaName).Role(role).Revoke(g.privilege))
+	case "TABLE_FUTURE":
+		dbName, schemaName, err := splitQualifiedName(g.target)
+		if err != nil {
+			return err
+		}
+		return DBExec(db, snowflake.FutureGrant(dbName, schemaName, "TABLES").Role(role).Revoke(g.privilege))
+	default:
+		return fmt.Errorf("unknown role template grant object type %s", g.objectType)
+	}
+}
+
+// grantTemplateGrant issues the GRANT and invalidates role's grantCache
+// entries, since the next ReadRoleTemplateBinding (when strict) must see
+// this grant rather than a memoized SHOW GRANTS TO ROLE from before it.
+func grantTemplateGrant(db *sql.DB, role string, g templateGrant) error {
+	if err := execTemplateGrant(db, role, g); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role)
+	return nil
+}
+
+// revokeTemplateGrant issues the REVOKE and invalidates role's grantCache
+// entries for the same reason as grantTemplateGrant.
+func revokeTemplateGrant(db *sql.DB, role string, g templateGrant) error {
+	if err := execTemplateRevoke(db, role, g); err != nil {
+		return err
+	}
+	grantCache.Invalidate(db, role)
+	return nil
+}
+
+func templateBindingID(templateName, roleName string) string {
+	return fmt.Sprintf("%v|%v", templateName, roleName)
+}
+
+// parseTemplateBindingID splits a "<template_name>|<role_name>" ID back
+// into its fields. It can't reuse splitQualifiedName: that splits on "."
+// for "<database>.<schema>" names, not the "|" this resource's ID uses.
+func parseTemplateBindingID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "|", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf(`expected an ID in the form "<template_name>|<role_name>", got %q`, id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func CreateRoleTemplateBinding(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	roleName := data.Get("role_name").(string)
+	templateName := data.Get("template_name").(string)
+
+	for _, grant := range expandTemplateGrants(data) {
+		if err := grantTemplateGrant(db, roleName, grant); err != nil {
+			return err
+		}
+	}
+
+	data.SetId(templateBindingID(templateName, roleName))
+	return ReadRoleTemplateBinding(data, meta)
+}
+
+// roleGrantRow is a single row of `SHOW GRANTS TO ROLE`.
+type roleGrantRow struct {
+	Privilege   sql.NullString `db:"privilege"`
+	GrantedOn   sql.NullString `db:"granted_on"`
+	Name        sql.NullString `db:"name"`
+	GrantOption sql.NullString `db:"grant_option"`
+}
+
+// toRoleKind namespaces grantCache entries populated by showGrantsToRole
+// from the `SHOW GRANTS OF ROLE` rows role_grants.go caches under
+// ofRoleKind, so both readers can share grantCache for the same role.
+const toRoleKind = "to_role"
+
+// showGrantsToRole serves roleName's `SHOW GRANTS TO ROLE` rows from
+// grantCache, falling back to fetchGrantsToRole on a miss.
+func showGrantsToRole(db *sql.DB, roleName string) ([]*roleGrantRow, error) {
+	rows, err := grantCache.Get(db, toRoleKind, roleName, func(roleName string) (interface{}, error) {
+		return fetchGrantsToRole(db, roleName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows.([]*roleGrantRow), nil
+}
+
+// fetchGrantsToRole issues a single `SHOW GRANTS TO ROLE` and scans every
+// row it returns; showGrantsToRole is the only caller.
+func fetchGrantsToRole(db *sql.DB, roleName string) ([]*roleGrantRow, error) {
+	sdb := sqlx.NewDb(db, "snowflake")
+	stmt := fmt.Sprintf(`SHOW GRANTS TO ROLE "%s"`, roleName)
+	rows, err := sdb.Queryx(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]*roleGrantRow, 0)
+	for rows.Next() {
+		g := &roleGrantRow{}
+		if err := rows.StructScan(g); err != nil {
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// futureGrantRow is a single row of `SHOW FUTURE GRANTS IN SCHEMA`.
+type futureGrantRow struct {
+	Privilege   sql.NullString `db:"privilege"`
+	GrantOn     sql.NullString `db:"grant_on"`
+	GranteeName sql.NullString `db:"grantee_name"`
+}
+
+// fetchFutureGrantsForRole issues `SHOW FUTURE GRANTS IN SCHEMA` for
+// "<dbName>.<schemaName>" and returns only the TABLES rows granted to
+// roleName: unlike `SHOW GRANTS TO ROLE`, this statement isn't scoped to a
+// single role or object type, so both filters have to be applied here.
+func fetchFutureGrantsForRole(db *sql.DB, dbName, schemaName, roleName string) ([]*futureGrantRow, error) {
+	sdb := sqlx.NewDb(db, "snowflake")
+	stmt := snowflake.FutureGrant(dbName, schemaName, "TABLES").Show()
+	rows, err := sdb.Queryx(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]*futureGrantRow, 0)
+	for rows.Next() {
+		g := &futureGrantRow{}
+		if err := rows.StructScan(g); err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(g.GrantOn.String, "TABLE") && strings.EqualFold(g.GranteeName.String, roleName) {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+// mergeFutureGrants fetches roleName's future-table grants for every
+// distinct schema referenced by tablesFuture and adds them to granted under
+// the same "TABLE_FUTURE|<schema>|<privilege>" keys hasGrant looks up.
+// Future grants never appear in `SHOW GRANTS TO ROLE` (they only show up in
+// `SHOW FUTURE GRANTS IN SCHEMA`), so without this, hasGrant("TABLE_FUTURE",
+// ...) always reports false and a strict binding with any tables_future
+// entries shows perpetual drift.
+func mergeFutureGrants(db *sql.DB, roleName string, tablesFuture []interface{}, granted map[string]bool) error {
+	fetched := map[string]bool{}
+	for _, raw := range tablesFuture {
+		schemaName := raw.(map[string]interface{})["schema"].(string)
+		if fetched[schemaName] {
+			continue
+		}
+		fetched[schemaName] = true
+
+		dbName, schemaOnly, err := splitQualifiedName(schemaName)
+		if err != nil {
+			return err
+		}
+		rows, err := fetchFutureGrantsForRole(db, dbName, schemaOnly, roleName)
+		if err != nil {
+			return err
+		}
+		for _, g := range rows {
+			key := strings.Join([]string{"TABLE_FUTURE", schemaName, g.Privilege.String}, "|")
+			granted[key] = true
+		}
+	}
+	return nil
+}
+
+// ReadRoleTemplateBinding reconciles the template's grant booleans against
+// what Snowflake reports for the role when strict is set; non-strict
+// bindings trust the config and tolerate grants made out-of-band so that a
+// shared template's objects can carry other grants without fighting Terraform.
+func ReadRoleTemplateBinding(data *schema.ResourceData, meta interface{}) error {
+	if !data.Get("strict").(bool) {
+		return nil
+	}
+
+	db := meta.(*sql.DB)
+	roleName := data.Get("role_name").(string)
+
+	observed, err := showGrantsToRole(db, roleName)
+	if err != nil {
+		return err
+	}
+
+	granted := map[string]bool{}
+	for _, g := range observed {
+		key := strings.Join([]string{g.GrantedOn.String, g.Name.String, g.Privilege.String}, "|")
+		granted[key] = true
+	}
+
+	tablesFuture := data.Get("tables_future").([]interface{})
+	if err := mergeFutureGrants(db, roleName, tablesFuture, granted); err != nil {
+		return err
+	}
+
+	hasGrant := func(objectType, target, privilege string) bool {
+		return granted[strings.Join([]string{objectType, target, privilege}, "|")]
+	}
+
+	warehouses := data.Get("warehouses").([]interface{})
+	for i, raw := range warehouses {
+		m := raw.(map[string]interface{})
+		name := m["name"].(string)
+		m["usage"] = hasGrant("WAREHOUSE", name, "USAGE")
+		m["operate"] = hasGrant("WAREHOUSE", name, "OPERATE")
+		warehouses[i] = m
+	}
+	if err := data.Set("warehouses", warehouses); err != nil {
+		return err
+	}
+
+	databases := data.Get("databases").([]interface{})
+	for i, raw := range databases {
+		m := raw.(map[string]interface{})
+		name := m["name"].(string)
+		m["usage"] = hasGrant("DATABASE", name, "USAGE")
+		m["monitor"] = hasGrant("DATABASE", name, "MONITOR")
+		databases[i] = m
+	}
+	if err := data.Set("databases", databases); err != nil {
+		return err
+	}
+
+	schemas := data.Get("schemas").([]interface{})
+	for i, raw := range schemas {
+		m := raw.(map[string]interface{})
+		name := m["name"].(string)
+		m["usage"] = hasGrant("SCHEMA", name, "USAGE")
+		m["create_table"] = hasGrant("SCHEMA", name, "CREATE TABLE")
+		m["create_view"] = hasGrant("SCHEMA", name, "CREATE VIEW")
+		schemas[i] = m
+	}
+	if err := data.Set("schemas", schemas); err != nil {
+		return err
+	}
+
+	for i, raw := range tablesFuture {
+		m := raw.(map[string]interface{})
+		schemaName := m["schema"].(string)
+		m["select"] = hasGrant("TABLE_FUTURE", schemaName, "SELECT")
+		m["insert"] = hasGrant("TABLE_FUTURE", schemaName, "INSERT")
+		m["update"] = hasGrant("TABLE_FUTURE", schemaName, "UPDATE")
+		m["delete"] = hasGrant("TABLE_FUTURE", schemaName, "DELETE")
+		tablesFuture[i] = m
+	}
+	return data.Set("tables_future", tablesFuture)
+}
+
+// UpdateRoleTemplateBinding diffs the desired grant set against the grant
+// set implied by the prior state and only revokes/grants the (object,
+// privilege) pairs that actually changed, rather than tearing down and
+// recreating every grant the binding owns.
+func UpdateRoleTemplateBinding(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	roleName := data.Get("role_name").(string)
+
+	var oldGrants, desiredGrants []templateGrant
+	for _, category := range templateCategories {
+		oldRaw, newRaw := data.GetChange(category)
+		oldGrants = append(oldGrants, expandTemplateGrantsFromList(oldRaw.([]interface{}), category)...)
+		desiredGrants = append(desiredGrants, expandTemplateGrantsFromList(newRaw.([]interface{}), category)...)
+	}
+
+	toRevoke, toGrant := diffTemplateGrants(oldGrants, desiredGrants)
+
+	for _, g := range toRevoke {
+		if err := revokeTemplateGrant(db, roleName, g); err != nil {
+			return err
+		}
+	}
+	for _, g := range toGrant {
+		if err := grantTemplateGrant(db, roleName, g); err != nil {
+			return err
+		}
+	}
+
+	return ReadRoleTemplateBinding(data, meta)
+}
+
+// diffTemplateGrants compares a binding's prior and desired templateGrants
+// and reports only the grants that actually changed: those present in old
+// but not desired must be revoked, those present in desired but not old
+// must be granted. Grants unchanged between old and desired are left
+// alone, so toggling one privilege doesn't touch the rest of the binding.
+func diffTemplateGrants(oldGrants, desiredGrants []templateGrant) (toRevoke, toGrant []templateGrant) {
+	oldByKey := map[string]templateGrant{}
+	for _, g := range oldGrants {
+		oldByKey[g.key()] = g
+	}
+	desiredByKey := map[string]templateGrant{}
+	for _, g := range desiredGrants {
+		desiredByKey[g.key()] = g
+	}
+
+	for key, g := range oldByKey {
+		if _, ok := desiredByKey[key]; !ok {
+			toRevoke = append(toRevoke, g)
+		}
+	}
+	for key, g := range desiredByKey {
+		if _, ok := oldByKey[key]; !ok {
+			toGrant = append(toGrant, g)
+		}
+	}
+	return toRevoke, toGrant
+}
+
+// expandTemplateGrantsFromList expands a single category's raw list
+// (as returned by ResourceData.GetChange) into templateGrants, reusing the
+// same privilege/object-type mapping as expandTemplateGrants.
+func expandTemplateGrantsFromList(in []interface{}, category string) []templateGrant {
+	grants := make([]templateGrant, 0)
+	for _, raw := range in {
+		m := raw.(map[string]interface{})
+		switch category {
+		case "warehouses":
+			name := m["name"].(string)
+			if m["usage"].(bool) {
+				grants = append(grants, templateGrant{"WAREHOUSE", name, "USAGE"})
+			}
+			if m["operate"].(bool) {
+				grants = append(grants, templateGrant{"WAREHOUSE", name, "OPERATE"})
+			}
+		case "databases":
+			name := m["name"].(string)
+			if m["usage"].(bool) {
+				grants = append(grants, templateGrant{"DATABASE", name, "USAGE"})
+			}
+			if m["monitor"].(bool) {
+				grants = append(grants, templateGrant{"DATABASE", name, "MONITOR"})
+			}
+		case "schemas":
+			name := m["name"].(string)
+			if m["usage"].(bool) {
+				grants = append(grants, templateGrant{"SCHEMA", name, "USAGE"})
+			}
+			if m["create_table"].(bool) {
+				grants = append(grants, templateGrant{"SCHEMA", name, "CREATE TABLE"})
+			}
+			if m["create_view"].(bool) {
+				grants = append(grants, templateGrant{"SCHEMA", name, "CREATE VIEW"})
+			}
+		case "tables_future":
+			schemaName := m["schema"].(string)
+			for attr, privilege := range map[string]string{"select": "SELECT", "insert": "INSERT", "update": "UPDATE", "delete": "DELETE"} {
+				if m[attr].(bool) {
+					grants = append(grants, templateGrant{"TABLE_FUTURE", schemaName, privilege})
+				}
+			}
+		}
+	}
+	return grants
+}
+
+func DeleteRoleTemplateBinding(data *schema.ResourceData, meta interface{}) error {
+	db := meta.(*sql.DB)
+	roleName := data.Get("role_name").(string)
+
+	for _, grant := range expandTemplateGrants(data) {
+		if err := revokeTemplateGrant(db, roleName, grant); err != nil {
+			return err
+		}
+	}
+
+	data.SetId("")
+	return nil
+}
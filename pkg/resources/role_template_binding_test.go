@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateBindingIDRoundTrip(t *testing.T) {
+	r := require.New(t)
+
+	id := templateBindingID("my_template", "my_role")
+	r.Equal("my_template|my_role", id)
+
+	templateName, roleName, err := parseTemplateBindingID(id)
+	r.NoError(err)
+	r.Equal("my_template", templateName)
+	r.Equal("my_role", roleName)
+}
+
+func TestParseTemplateBindingIDRejectsMalformedID(t *testing.T) {
+	r := require.New(t)
+
+	_, _, err := parseTemplateBindingID("my_template")
+	r.Error(err)
+}
+
+func TestExpandTemplateGrantsFromListWarehouses(t *testing.T) {
+	r := require.New(t)
+
+	in := []interface{}{
+		map[string]interface{}{"name": "compute_wh", "usage": true, "operate": false},
+		map[string]interface{}{"name": "loading_wh", "usage": false, "operate": true},
+	}
+
+	grants := expandTemplateGrantsFromList(in, "warehouses")
+	r.ElementsMatch([]templateGrant{
+		{"WAREHOUSE", "compute_wh", "USAGE"},
+		{"WAREHOUSE", "loading_wh", "OPERATE"},
+	}, grants)
+}
+
+func TestExpandTemplateGrantsFromListTablesFuture(t *testing.T) {
+	r := require.New(t)
+
+	in := []interface{}{
+		map[string]interface{}{"schema": "db.sch", "select": true, "insert": true, "update": false, "delete": false},
+	}
+
+	grants := expandTemplateGrantsFromList(in, "tables_future")
+	r.ElementsMatch([]templateGrant{
+		{"TABLE_FUTURE", "db.sch", "SELECT"},
+		{"TABLE_FUTURE", "db.sch", "INSERT"},
+	}, grants)
+}
+
+func TestDiffTemplateGrantsOnlyReturnsChangedGrants(t *testing.T) {
+	r := require.New(t)
+
+	unchanged := templateGrant{"WAREHOUSE", "compute_wh", "USAGE"}
+	removed := templateGrant{"DATABASE", "analytics", "MONITOR"}
+	added := templateGrant{"SCHEMA", "analytics.public", "USAGE"}
+
+	oldGrants := []templateGrant{unchanged, removed}
+	desiredGrants := []templateGrant{unchanged, added}
+
+	toRevoke, toGrant := diffTemplateGrants(oldGrants, desiredGrants)
+	r.Equal([]templateGrant{removed}, toRevoke)
+	r.Equal([]templateGrant{added}, toGrant)
+}
+
+func TestDiffTemplateGrantsNoChanges(t *testing.T) {
+	r := require.New(t)
+
+	grants := []templateGrant{{"WAREHOUSE", "compute_wh", "USAGE"}}
+
+	toRevoke, toGrant := diffTemplateGrants(grants, grants)
+	r.Empty(toRevoke)
+	r.Empty(toGrant)
+}
@@ -0,0 +1,27 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleTemplateLifecycleIsLocalOnly(t *testing.T) {
+	r := require.New(t)
+
+	data := schema.TestResourceDataRaw(t, RoleTemplate().Schema, map[string]interface{}{
+		"name": "analyst_template",
+	})
+
+	r.NoError(CreateRoleTemplate(data, nil))
+	r.Equal("analyst_template", data.Id())
+
+	// None of Read/Update/Delete touch Snowflake, so passing a nil meta
+	// must not panic.
+	r.NoError(ReadRoleTemplate(data, nil))
+	r.NoError(UpdateRoleTemplate(data, nil))
+
+	r.NoError(DeleteRoleTemplate(data, nil))
+	r.Equal("", data.Id())
+}
@@ -0,0 +1,54 @@
+package snowflake
+
+import (
+	"fmt"
+)
+
+// DatabaseGrantBuilder abstracts the creation of SQL queries for granting
+// privileges on a database
+type DatabaseGrantBuilder struct {
+	name string
+}
+
+// DatabaseGrant returns a pointer to a Builder that abstracts the creation
+// of SQL queries for granting privileges on a database
+func DatabaseGrant(name string) *DatabaseGrantBuilder {
+	return &DatabaseGrantBuilder{
+		name: name,
+	}
+}
+
+// Role returns a pointer to a DatabaseGrantExecutable for granting/revoking
+// privileges on this database to/from a role
+func (dgb *DatabaseGrantBuilder) Role(role string) *DatabaseGrantExecutable {
+	return &DatabaseGrantExecutable{
+		name: dgb.name,
+		role: role,
+	}
+}
+
+// Show returns the SQL query that will show all grants on this database
+func (dgb *DatabaseGrantBuilder) Show() string {
+	return fmt.Sprintf(`SHOW GRANTS ON DATABASE "%v"`, dgb.name)
+}
+
+// DatabaseGrantExecutable abstracts the creation of SQL queries to grant or
+// revoke a privilege on a database to/from a role
+type DatabaseGrantExecutable struct {
+	name string
+	role string
+}
+
+// Grant returns the SQL query that will grant the given privilege on this database
+func (dge *DatabaseGrantExecutable) Grant(privilege string, withGrantOption bool) string {
+	stmt := fmt.Sprintf(`GRANT %v ON DATABASE "%v" TO ROLE "%v"`, privilege, dge.name, dge.role)
+	if withGrantOption {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt
+}
+
+// Revoke returns the SQL query that will revoke the given privilege on this database
+func (dge *DatabaseGrantExecutable) Revoke(privilege string) string {
+	return fmt.Sprintf(`REVOKE %v ON DATABASE "%v" FROM ROLE "%v"`, privilege, dge.name, dge.role)
+}
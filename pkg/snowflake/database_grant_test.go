@@ -0,0 +1,24 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseGrantRole(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.DatabaseGrant("test_db").Role("test_role")
+	r.NotNil(g)
+
+	r.Equal(`GRANT USAGE ON DATABASE "test_db" TO ROLE "test_role"`, g.Grant("USAGE", false))
+	r.Equal(`GRANT MONITOR ON DATABASE "test_db" TO ROLE "test_role" WITH GRANT OPTION`, g.Grant("MONITOR", true))
+	r.Equal(`REVOKE USAGE ON DATABASE "test_db" FROM ROLE "test_role"`, g.Revoke("USAGE"))
+}
+
+func TestDatabaseGrantShow(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.DatabaseGrant("test_db")
+	r.Equal(`SHOW GRANTS ON DATABASE "test_db"`, g.Show())
+}
@@ -0,0 +1,58 @@
+package snowflake
+
+import (
+	"fmt"
+)
+
+// FutureGrantBuilder abstracts the creation of SQL queries for granting
+// privileges on all future objects of a given type created within a schema
+type FutureGrantBuilder struct {
+	db         string
+	schema     string
+	objectType string // e.g. TABLES, VIEWS
+}
+
+// FutureGrant returns a pointer to a Builder that abstracts the creation of
+// SQL queries for granting privileges on future objects within a schema
+func FutureGrant(db, schema, objectType string) *FutureGrantBuilder {
+	return &FutureGrantBuilder{
+		db:         db,
+		schema:     schema,
+		objectType: objectType,
+	}
+}
+
+// Role returns a pointer to a FutureGrantExecutable for granting/revoking
+// privileges on future objects within this schema to/from a role
+func (fgb *FutureGrantBuilder) Role(role string) *FutureGrantExecutable {
+	return &FutureGrantExecutable{
+		db:         fgb.db,
+		schema:     fgb.schema,
+		objectType: fgb.objectType,
+		role:       role,
+	}
+}
+
+// Show returns the SQL query that will show all future grants within this schema
+func (fgb *FutureGrantBuilder) Show() string {
+	return fmt.Sprintf(`SHOW FUTURE GRANTS IN SCHEMA "%v"."%v"`, fgb.db, fgb.schema)
+}
+
+// FutureGrantExecutable abstracts the creation of SQL queries to grant or
+// revoke a privilege on future objects within a schema to/from a role
+type FutureGrantExecutable struct {
+	db         string
+	schema     string
+	objectType string
+	role       string
+}
+
+// Grant returns the SQL query that will grant the given privilege on future objects within this schema
+func (fge *FutureGrantExecutable) Grant(privilege string) string {
+	return fmt.Sprintf(`GRANT %v ON FUTURE %v IN SCHEMA "%v"."%v" TO ROLE "%v"`, privilege, fge.objectType, fge.db, fge.schema, fge.role)
+}
+
+// Revoke returns the SQL query that will revoke the given privilege on future objects within this schema
+func (fge *FutureGrantExecutable) Revoke(privilege string) string {
+	return fmt.Sprintf(`REVOKE %v ON FUTURE %v IN SCHEMA "%v"."%v" FROM ROLE "%v"`, privilege, fge.objectType, fge.db, fge.schema, fge.role)
+}
@@ -0,0 +1,23 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFutureGrantRole(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.FutureGrant("test_db", "test_schema", "TABLES").Role("test_role")
+	r.NotNil(g)
+
+	r.Equal(`GRANT SELECT ON FUTURE TABLES IN SCHEMA "test_db"."test_schema" TO ROLE "test_role"`, g.Grant("SELECT"))
+	r.Equal(`REVOKE SELECT ON FUTURE TABLES IN SCHEMA "test_db"."test_schema" FROM ROLE "test_role"`, g.Revoke("SELECT"))
+}
+
+func TestFutureGrantShow(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.FutureGrant("test_db", "test_schema", "TABLES")
+	r.Equal(`SHOW FUTURE GRANTS IN SCHEMA "test_db"."test_schema"`, g.Show())
+}
@@ -0,0 +1,125 @@
+// Package grantcache memoizes `SHOW GRANTS ...` query results for the
+// lifetime of a provider session so that an apply touching hundreds of
+// snowflake_role_grants and snowflake_role_template_binding resources that
+// reference the same handful of roles issues one query per (session, kind,
+// role) between invalidations instead of one per resource CRUD call. Without it, readGrants ran a fresh
+// `SHOW GRANTS OF ROLE` on every Create/Read/Update, even when the prior
+// call in the same apply had just fetched the same role; on accounts with
+// hundreds of roles that pattern dominates plan time.
+package grantcache
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// disableEnvVar bypasses the cache entirely, falling back to a fresh query
+// on every call. Useful when debugging drift that might be caused by a
+// stale cache entry.
+const disableEnvVar = "SNOWFLAKE_DISABLE_GRANT_CACHE"
+
+// FetchFunc issues whichever `SHOW GRANTS ...` statement the caller needs
+// for roleName and returns every resulting row, typed however the
+// caller's resource package scans them; the cache does no paging of its own.
+type FetchFunc func(roleName string) (interface{}, error)
+
+// session memoizes fetch results for a single *sql.DB. Rows are keyed by
+// "<kind>:<roleName>" so the two row shapes used by snowflake_role_grants
+// (`SHOW GRANTS OF ROLE`) and snowflake_role_template_binding
+// (`SHOW GRANTS TO ROLE`) can share one Cache without colliding.
+type session struct {
+	mu   sync.RWMutex
+	rows map[string]interface{}
+}
+
+func rowKey(kind, roleName string) string {
+	return kind + ":" + roleName
+}
+
+// Cache memoizes SHOW GRANTS rows per (session key, kind, role name).
+// Providers hold a single Cache for their lifetime and pass their *sql.DB
+// as the session key on every Get/Invalidate call.
+type Cache struct {
+	mu       sync.Mutex
+	sessions map[interface{}]*session
+}
+
+// New returns an empty Cache. Providers hold a single Cache for their
+// lifetime and pass it to Get/Invalidate on every resource CRUD call.
+func New() *Cache {
+	return &Cache{sessions: make(map[interface{}]*session)}
+}
+
+// Disabled reports whether SNOWFLAKE_DISABLE_GRANT_CACHE is set.
+func Disabled() bool {
+	return os.Getenv(disableEnvVar) != ""
+}
+
+func (c *Cache) sessionFor(key interface{}) *session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.sessions[key]
+	if !ok {
+		s = &session{rows: make(map[string]interface{})}
+		c.sessions[key] = s
+	}
+	return s
+}
+
+// Get returns the memoized rows for (kind, roleName) under the session
+// identified by key (typically the *sql.DB the provider is using), calling
+// fetch to populate the cache on a miss. kind distinguishes the shape of
+// rows being cached (e.g. "of_role" vs "to_role") so two different readers
+// can share one Cache for the same role without reading each other's rows.
+// When the cache is disabled via SNOWFLAKE_DISABLE_GRANT_CACHE, fetch is
+// called unconditionally and the result is never stored, so every call
+// falls back to a per-role query exactly as it did before the cache
+// existed.
+func (c *Cache) Get(key interface{}, kind, roleName string, fetch FetchFunc) (interface{}, error) {
+	if Disabled() {
+		return fetch(roleName)
+	}
+
+	s := c.sessionFor(key)
+	rk := rowKey(kind, roleName)
+
+	s.mu.RLock()
+	rows, ok := s.rows[rk]
+	s.mu.RUnlock()
+	if ok {
+		return rows, nil
+	}
+
+	rows, err := fetch(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.rows[rk] = rows
+	s.mu.Unlock()
+	return rows, nil
+}
+
+// Invalidate drops every memoized row for roleName, across all kinds,
+// under the given session, so the next Get re-fetches from Snowflake. Call
+// this after any Create/Update/Delete that grants or revokes against
+// roleName.
+func (c *Cache) Invalidate(key interface{}, roleName string) {
+	c.mu.Lock()
+	s, ok := c.sessions[key]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	suffix := ":" + roleName
+	s.mu.Lock()
+	for rk := range s.rows {
+		if strings.HasSuffix(rk, suffix) {
+			delete(s.rows, rk)
+		}
+	}
+	s.mu.Unlock()
+}
@@ -0,0 +1,160 @@
+package grantcache_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake/grantcache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheGetMemoizesPerSessionAndRole(t *testing.T) {
+	r := require.New(t)
+	c := grantcache.New()
+
+	calls := 0
+	fetch := func(roleName string) (interface{}, error) {
+		calls++
+		return []string{roleName}, nil
+	}
+
+	db1 := "db1"
+	db2 := "db2"
+
+	rows, err := c.Get(db1, "of_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal([]string{"analyst"}, rows)
+	r.Equal(1, calls)
+
+	// Same session, same role: served from memory.
+	_, err = c.Get(db1, "of_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(1, calls)
+
+	// Same session, different kind: not served from the of_role entry.
+	_, err = c.Get(db1, "to_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(2, calls)
+
+	// Different session: not shared with db1's cache.
+	_, err = c.Get(db2, "of_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(3, calls)
+}
+
+func TestCacheGetPropagatesFetchError(t *testing.T) {
+	r := require.New(t)
+	c := grantcache.New()
+
+	wantErr := fmt.Errorf("boom")
+	_, err := c.Get("db", "of_role", "analyst", func(roleName string) (interface{}, error) {
+		return nil, wantErr
+	})
+	r.Equal(wantErr, err)
+}
+
+func TestCacheInvalidateClearsAllKindsForRole(t *testing.T) {
+	r := require.New(t)
+	c := grantcache.New()
+
+	calls := 0
+	fetch := func(roleName string) (interface{}, error) {
+		calls++
+		return []string{roleName}, nil
+	}
+
+	_, err := c.Get("db", "of_role", "analyst", fetch)
+	r.NoError(err)
+	_, err = c.Get("db", "to_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(2, calls)
+
+	c.Invalidate("db", "analyst")
+
+	_, err = c.Get("db", "of_role", "analyst", fetch)
+	r.NoError(err)
+	_, err = c.Get("db", "to_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(4, calls)
+
+	// A different role is unaffected by the invalidation above: its first
+	// read is a cache miss, but the second is served from memory.
+	_, err = c.Get("db", "of_role", "other_role", fetch)
+	r.NoError(err)
+	r.Equal(5, calls)
+	_, err = c.Get("db", "of_role", "other_role", fetch)
+	r.NoError(err)
+	r.Equal(5, calls)
+}
+
+func TestCacheDisabledBypassesMemoization(t *testing.T) {
+	r := require.New(t)
+	r.NoError(os.Setenv("SNOWFLAKE_DISABLE_GRANT_CACHE", "1"))
+	defer os.Unsetenv("SNOWFLAKE_DISABLE_GRANT_CACHE")
+
+	r.True(grantcache.Disabled())
+
+	c := grantcache.New()
+	calls := 0
+	fetch := func(roleName string) (interface{}, error) {
+		calls++
+		return []string{roleName}, nil
+	}
+
+	_, err := c.Get("db", "of_role", "analyst", fetch)
+	r.NoError(err)
+	_, err = c.Get("db", "of_role", "analyst", fetch)
+	r.NoError(err)
+	r.Equal(2, calls)
+}
+
+// simulatedQueryLatency stands in for the network round trip a real
+// `SHOW GRANTS OF ROLE` query takes, so the benchmarks below reflect query
+// count rather than the cost of formatting a fake row.
+const simulatedQueryLatency = 50 * time.Microsecond
+
+func simulateShowGrants(roleName string) (interface{}, error) {
+	time.Sleep(simulatedQueryLatency)
+	return []string{roleName + ":USAGE"}, nil
+}
+
+// BenchmarkReadRoleGrants_Uncached models the pre-cache behavior: each of
+// 500 roles, referenced across a Create->Read and an Update->Read in the
+// same apply, issues its own `SHOW GRANTS OF ROLE` query every time.
+func BenchmarkReadRoleGrants_Uncached(b *testing.B) {
+	const roles = 500
+	const readsPerRole = 3
+
+	for i := 0; i < b.N; i++ {
+		for r := 0; r < roles; r++ {
+			roleName := fmt.Sprintf("role_%d", r)
+			for n := 0; n < readsPerRole; n++ {
+				if _, err := simulateShowGrants(roleName); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkReadRoleGrants_Cached models the same apply routed through a
+// grantcache.Cache: only the first read of each role reaches
+// simulateShowGrants, the rest are served from memory.
+func BenchmarkReadRoleGrants_Cached(b *testing.B) {
+	const roles = 500
+	const readsPerRole = 3
+
+	for i := 0; i < b.N; i++ {
+		c := grantcache.New()
+		for r := 0; r < roles; r++ {
+			roleName := fmt.Sprintf("role_%d", r)
+			for n := 0; n < readsPerRole; n++ {
+				if _, err := c.Get("db", "of_role", roleName, simulateShowGrants); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
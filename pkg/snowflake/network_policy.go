@@ -0,0 +1,129 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NetworkPolicyBuilder abstracts the creation of SQL queries for a Snowflake
+// network policy
+type NetworkPolicyBuilder struct {
+	name string
+}
+
+// NetworkPolicy returns a pointer to a Builder that abstracts the creation of
+// SQL queries for a single network policy
+func NetworkPolicy(name string) *NetworkPolicyBuilder {
+	return &NetworkPolicyBuilder{
+		name: name,
+	}
+}
+
+// Create returns the SQL query that will create a new network policy
+func (npb *NetworkPolicyBuilder) Create(allowedIPList []string, blockedIPList []string, comment string) string {
+	stmt := fmt.Sprintf(`CREATE NETWORK POLICY "%v" ALLOWED_IP_LIST = (%v) BLOCKED_IP_LIST = (%v)`, npb.name, formatIPList(allowedIPList), formatIPList(blockedIPList))
+	if comment != "" {
+		stmt += fmt.Sprintf(` COMMENT = '%v'`, EscapeString(comment))
+	}
+	return stmt
+}
+
+// Show returns the SQL query that will SHOW a network policy
+func (npb *NetworkPolicyBuilder) Show() string {
+	return fmt.Sprintf(`SHOW NETWORK POLICIES LIKE '%v'`, npb.name)
+}
+
+// Describe returns the SQL query that will DESCRIBE a network policy's IP lists
+func (npb *NetworkPolicyBuilder) Describe() string {
+	return fmt.Sprintf(`DESC NETWORK POLICY "%v"`, npb.name)
+}
+
+// Drop returns the SQL query that will drop a network policy
+func (npb *NetworkPolicyBuilder) Drop() string {
+	return fmt.Sprintf(`DROP NETWORK POLICY "%v"`, npb.name)
+}
+
+// ChangeComment returns the SQL query that will update the comment on a network policy
+func (npb *NetworkPolicyBuilder) ChangeComment(comment string) string {
+	return fmt.Sprintf(`ALTER NETWORK POLICY "%v" SET COMMENT = '%v'`, npb.name, EscapeString(comment))
+}
+
+// RemoveComment returns the SQL query that will remove the comment on a network policy
+func (npb *NetworkPolicyBuilder) RemoveComment() string {
+	return fmt.Sprintf(`ALTER NETWORK POLICY "%v" UNSET COMMENT`, npb.name)
+}
+
+// ChangeIpList returns the SQL query that will set the ALLOWED_IP_LIST or
+// BLOCKED_IP_LIST of a network policy. listType must be "ALLOWED" or "BLOCKED"
+func (npb *NetworkPolicyBuilder) ChangeIpList(listType string, ips []string) string {
+	return fmt.Sprintf(`ALTER NETWORK POLICY "%v" SET %v_IP_LIST = (%v)`, npb.name, listType, formatIPList(ips))
+}
+
+// SetOnAccount returns the SQL query that will assign this network policy to the account
+func (npb *NetworkPolicyBuilder) SetOnAccount() string {
+	return fmt.Sprintf(`ALTER ACCOUNT SET NETWORK_POLICY = "%v"`, npb.name)
+}
+
+// UnsetOnAccount returns the SQL query that will remove any network policy assigned to the account
+func (npb *NetworkPolicyBuilder) UnsetOnAccount() string {
+	return `ALTER ACCOUNT UNSET NETWORK_POLICY`
+}
+
+// SetOnUser returns the SQL query that will assign this network policy to a user
+func (npb *NetworkPolicyBuilder) SetOnUser(user string) string {
+	return fmt.Sprintf(`ALTER USER "%v" SET NETWORK_POLICY = "%v"`, user, npb.name)
+}
+
+// UnsetOnUser returns the SQL query that will remove any network policy assigned to a user
+func (npb *NetworkPolicyBuilder) UnsetOnUser(user string) string {
+	return fmt.Sprintf(`ALTER USER "%v" UNSET NETWORK_POLICY`, user)
+}
+
+// ShowOnAccount returns the SQL query that reports the network policy
+// currently assigned to the account, if any.
+func ShowOnAccount() string {
+	return `SHOW PARAMETERS LIKE 'NETWORK_POLICY' IN ACCOUNT`
+}
+
+// ShowOnUser returns the SQL query that reports the network policy
+// currently assigned to user, if any.
+func ShowOnUser(user string) string {
+	return fmt.Sprintf(`SHOW PARAMETERS LIKE 'NETWORK_POLICY' IN USER "%v"`, user)
+}
+
+func formatIPList(ips []string) string {
+	quoted := make([]string, len(ips))
+	for i, ip := range ips {
+		quoted[i] = fmt.Sprintf(`'%v'`, ip)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// EscapeString doubles any single quotes in s so it can be embedded inside
+// a single-quoted SQL string literal (e.g. a COMMENT value) without
+// terminating the literal early.
+func EscapeString(s string) string {
+	return strings.ReplaceAll(s, `'`, `''`)
+}
+
+// NetworkPolicyStruct describes a single row returned by SHOW NETWORK POLICIES
+type NetworkPolicyStruct struct {
+	CreatedOn              string `db:"created_on"`
+	Name                   string `db:"name"`
+	Comment                string `db:"comment"`
+	EntriesInAllowedIPList string `db:"entries_in_allowed_ip_list"`
+	EntriesInBlockedIPList string `db:"entries_in_blocked_ip_list"`
+}
+
+// NetworkPolicyDescription describes a single row returned by DESC NETWORK POLICY
+type NetworkPolicyDescription struct {
+	Name  string `db:"name"`
+	Value string `db:"value"`
+}
+
+// NetworkPolicyParameter describes a single row returned by
+// `SHOW PARAMETERS LIKE 'NETWORK_POLICY' ...`; Value is the name of the
+// network policy currently assigned, or "" if none is.
+type NetworkPolicyParameter struct {
+	Value string `db:"value"`
+}
@@ -7,6 +7,39 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestNetworkPolicyCreate(t *testing.T) {
+	r := require.New(t)
+	s := snowflake.NetworkPolicy("test_network_policy")
+	r.NotNil(s)
+
+	q := s.Create([]string{"192.168.0.100/24"}, []string{"29.254.123.20"}, "")
+	r.Equal(`CREATE NETWORK POLICY "test_network_policy" ALLOWED_IP_LIST = ('192.168.0.100/24') BLOCKED_IP_LIST = ('29.254.123.20')`, q)
+
+	q = s.Create(nil, nil, "test comment!")
+	r.Equal(`CREATE NETWORK POLICY "test_network_policy" ALLOWED_IP_LIST = () BLOCKED_IP_LIST = () COMMENT = 'test comment!'`, q)
+
+	q = s.Create(nil, nil, "it's a test")
+	r.Equal(`CREATE NETWORK POLICY "test_network_policy" ALLOWED_IP_LIST = () BLOCKED_IP_LIST = () COMMENT = 'it''s a test'`, q)
+}
+
+func TestNetworkPolicyShow(t *testing.T) {
+	r := require.New(t)
+	s := snowflake.NetworkPolicy("test_network_policy")
+	r.NotNil(s)
+
+	q := s.Show()
+	r.Equal(`SHOW NETWORK POLICIES LIKE 'test_network_policy'`, q)
+}
+
+func TestNetworkPolicyDescribe(t *testing.T) {
+	r := require.New(t)
+	s := snowflake.NetworkPolicy("test_network_policy")
+	r.NotNil(s)
+
+	q := s.Describe()
+	r.Equal(`DESC NETWORK POLICY "test_network_policy"`, q)
+}
+
 func TestNetworkPolicyDrop(t *testing.T) {
 	r := require.New(t)
 	s := snowflake.NetworkPolicy("test_network_policy")
@@ -23,6 +56,9 @@ func TestNetworkPolicyChangeComment(t *testing.T) {
 
 	q := s.ChangeComment("test comment!")
 	r.Equal(`ALTER NETWORK POLICY "test_network_policy" SET COMMENT = 'test comment!'`, q)
+
+	q = s.ChangeComment("it's a test")
+	r.Equal(`ALTER NETWORK POLICY "test_network_policy" SET COMMENT = 'it''s a test'`, q)
 }
 
 func TestNetworkPolicyRemoveComment(t *testing.T) {
@@ -83,3 +119,17 @@ func TestNetworkPolicyUnsetOnUser(t *testing.T) {
 	q := s.UnsetOnUser("testuser")
 	r.Equal(`ALTER USER "testuser" UNSET NETWORK_POLICY`, q)
 }
+
+func TestNetworkPolicyShowOnAccount(t *testing.T) {
+	r := require.New(t)
+
+	q := snowflake.ShowOnAccount()
+	r.Equal(`SHOW PARAMETERS LIKE 'NETWORK_POLICY' IN ACCOUNT`, q)
+}
+
+func TestNetworkPolicyShowOnUser(t *testing.T) {
+	r := require.New(t)
+
+	q := snowflake.ShowOnUser("testuser")
+	r.Equal(`SHOW PARAMETERS LIKE 'NETWORK_POLICY' IN USER "testuser"`, q)
+}
@@ -0,0 +1,68 @@
+package snowflake
+
+import (
+	"fmt"
+)
+
+// RoleGrantBuilder abstracts the creation of RoleGrantExecutables
+type RoleGrantBuilder struct {
+	role string
+}
+
+// RoleGrantExecutable abstracts the creation of SQL queries to grant or revoke a
+// role to/from a grantee (a role or a user)
+type RoleGrantExecutable struct {
+	role            string
+	grantee         string
+	granteeType     string
+	withAdminOption bool
+}
+
+// RoleGrant returns a pointer to a Builder that abstracts the creation of SQL
+// queries for granting a role to a grantee
+func RoleGrant(role string) *RoleGrantBuilder {
+	return &RoleGrantBuilder{
+		role: role,
+	}
+}
+
+// Role returns a pointer to a RoleGrantExecutable for granting/revoking this
+// role to/from another role
+func (gb *RoleGrantBuilder) Role(role string) *RoleGrantExecutable {
+	return &RoleGrantExecutable{
+		role:        gb.role,
+		grantee:     role,
+		granteeType: "ROLE",
+	}
+}
+
+// User returns a pointer to a RoleGrantExecutable for granting/revoking this
+// role to/from a user
+func (gb *RoleGrantBuilder) User(user string) *RoleGrantExecutable {
+	return &RoleGrantExecutable{
+		role:        gb.role,
+		grantee:     user,
+		granteeType: "USER",
+	}
+}
+
+// WithAdminOption sets whether the grantee should be allowed to re-grant the
+// role to other roles/users (WITH ADMIN OPTION)
+func (gbe *RoleGrantExecutable) WithAdminOption(admin bool) *RoleGrantExecutable {
+	gbe.withAdminOption = admin
+	return gbe
+}
+
+// Grant returns the SQL query that will grant this role to the grantee
+func (gbe *RoleGrantExecutable) Grant() string {
+	stmt := fmt.Sprintf(`GRANT ROLE "%v" TO %v "%v"`, gbe.role, gbe.granteeType, gbe.grantee)
+	if gbe.withAdminOption {
+		stmt += " WITH ADMIN OPTION"
+	}
+	return stmt
+}
+
+// Revoke returns the SQL query that will revoke this role from the grantee
+func (gbe *RoleGrantExecutable) Revoke() string {
+	return fmt.Sprintf(`REVOKE ROLE "%v" FROM %v "%v"`, gbe.role, gbe.granteeType, gbe.grantee)
+}
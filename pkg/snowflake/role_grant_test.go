@@ -0,0 +1,37 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleGrantToRole(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.RoleGrant("test_role").Role("other_role")
+	r.NotNil(g)
+
+	r.Equal(`GRANT ROLE "test_role" TO ROLE "other_role"`, g.Grant())
+	r.Equal(`REVOKE ROLE "test_role" FROM ROLE "other_role"`, g.Revoke())
+}
+
+func TestRoleGrantToUser(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.RoleGrant("test_role").User("some_user")
+	r.NotNil(g)
+
+	r.Equal(`GRANT ROLE "test_role" TO USER "some_user"`, g.Grant())
+	r.Equal(`REVOKE ROLE "test_role" FROM USER "some_user"`, g.Revoke())
+}
+
+func TestRoleGrantWithAdminOption(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.RoleGrant("test_role").Role("other_role").WithAdminOption(true)
+	r.NotNil(g)
+
+	r.Equal(`GRANT ROLE "test_role" TO ROLE "other_role" WITH ADMIN OPTION`, g.Grant())
+
+	g = snowflake.RoleGrant("test_role").User("some_user").WithAdminOption(false)
+	r.Equal(`GRANT ROLE "test_role" TO USER "some_user"`, g.Grant())
+}
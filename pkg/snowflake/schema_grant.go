@@ -0,0 +1,58 @@
+package snowflake
+
+import (
+	"fmt"
+)
+
+// SchemaGrantBuilder abstracts the creation of SQL queries for granting
+// privileges on a schema
+type SchemaGrantBuilder struct {
+	db     string
+	schema string
+}
+
+// SchemaGrant returns a pointer to a Builder that abstracts the creation of
+// SQL queries for granting privileges on a schema
+func SchemaGrant(db, schema string) *SchemaGrantBuilder {
+	return &SchemaGrantBuilder{
+		db:     db,
+		schema: schema,
+	}
+}
+
+// Role returns a pointer to a SchemaGrantExecutable for granting/revoking
+// privileges on this schema to/from a role
+func (sgb *SchemaGrantBuilder) Role(role string) *SchemaGrantExecutable {
+	return &SchemaGrantExecutable{
+		db:     sgb.db,
+		schema: sgb.schema,
+		role:   role,
+	}
+}
+
+// Show returns the SQL query that will show all grants on this schema
+func (sgb *SchemaGrantBuilder) Show() string {
+	return fmt.Sprintf(`SHOW GRANTS ON SCHEMA "%v"."%v"`, sgb.db, sgb.schema)
+}
+
+// SchemaGrantExecutable abstracts the creation of SQL queries to grant or
+// revoke a privilege on a schema to/from a role
+type SchemaGrantExecutable struct {
+	db     string
+	schema string
+	role   string
+}
+
+// Grant returns the SQL query that will grant the given privilege on this schema
+func (sge *SchemaGrantExecutable) Grant(privilege string, withGrantOption bool) string {
+	stmt := fmt.Sprintf(`GRANT %v ON SCHEMA "%v"."%v" TO ROLE "%v"`, privilege, sge.db, sge.schema, sge.role)
+	if withGrantOption {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt
+}
+
+// Revoke returns the SQL query that will revoke the given privilege on this schema
+func (sge *SchemaGrantExecutable) Revoke(privilege string) string {
+	return fmt.Sprintf(`REVOKE %v ON SCHEMA "%v"."%v" FROM ROLE "%v"`, privilege, sge.db, sge.schema, sge.role)
+}
@@ -0,0 +1,24 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaGrantRole(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.SchemaGrant("test_db", "test_schema").Role("test_role")
+	r.NotNil(g)
+
+	r.Equal(`GRANT USAGE ON SCHEMA "test_db"."test_schema" TO ROLE "test_role"`, g.Grant("USAGE", false))
+	r.Equal(`GRANT CREATE TABLE ON SCHEMA "test_db"."test_schema" TO ROLE "test_role" WITH GRANT OPTION`, g.Grant("CREATE TABLE", true))
+	r.Equal(`REVOKE USAGE ON SCHEMA "test_db"."test_schema" FROM ROLE "test_role"`, g.Revoke("USAGE"))
+}
+
+func TestSchemaGrantShow(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.SchemaGrant("test_db", "test_schema")
+	r.Equal(`SHOW GRANTS ON SCHEMA "test_db"."test_schema"`, g.Show())
+}
@@ -0,0 +1,54 @@
+package snowflake
+
+import (
+	"fmt"
+)
+
+// WarehouseGrantBuilder abstracts the creation of SQL queries for granting
+// privileges on a warehouse
+type WarehouseGrantBuilder struct {
+	name string
+}
+
+// WarehouseGrant returns a pointer to a Builder that abstracts the creation
+// of SQL queries for granting privileges on a warehouse
+func WarehouseGrant(name string) *WarehouseGrantBuilder {
+	return &WarehouseGrantBuilder{
+		name: name,
+	}
+}
+
+// Role returns a pointer to a WarehouseGrantExecutable for granting/revoking
+// privileges on this warehouse to/from a role
+func (wgb *WarehouseGrantBuilder) Role(role string) *WarehouseGrantExecutable {
+	return &WarehouseGrantExecutable{
+		name: wgb.name,
+		role: role,
+	}
+}
+
+// Show returns the SQL query that will show all grants on this warehouse
+func (wgb *WarehouseGrantBuilder) Show() string {
+	return fmt.Sprintf(`SHOW GRANTS ON WAREHOUSE "%v"`, wgb.name)
+}
+
+// WarehouseGrantExecutable abstracts the creation of SQL queries to grant or
+// revoke a privilege on a warehouse to/from a role
+type WarehouseGrantExecutable struct {
+	name string
+	role string
+}
+
+// Grant returns the SQL query that will grant the given privilege on this warehouse
+func (wge *WarehouseGrantExecutable) Grant(privilege string, withGrantOption bool) string {
+	stmt := fmt.Sprintf(`GRANT %v ON WAREHOUSE "%v" TO ROLE "%v"`, privilege, wge.name, wge.role)
+	if withGrantOption {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt
+}
+
+// Revoke returns the SQL query that will revoke the given privilege on this warehouse
+func (wge *WarehouseGrantExecutable) Revoke(privilege string) string {
+	return fmt.Sprintf(`REVOKE %v ON WAREHOUSE "%v" FROM ROLE "%v"`, privilege, wge.name, wge.role)
+}
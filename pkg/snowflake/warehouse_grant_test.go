@@ -0,0 +1,24 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarehouseGrantRole(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.WarehouseGrant("test_warehouse").Role("test_role")
+	r.NotNil(g)
+
+	r.Equal(`GRANT USAGE ON WAREHOUSE "test_warehouse" TO ROLE "test_role"`, g.Grant("USAGE", false))
+	r.Equal(`GRANT OPERATE ON WAREHOUSE "test_warehouse" TO ROLE "test_role" WITH GRANT OPTION`, g.Grant("OPERATE", true))
+	r.Equal(`REVOKE USAGE ON WAREHOUSE "test_warehouse" FROM ROLE "test_role"`, g.Revoke("USAGE"))
+}
+
+func TestWarehouseGrantShow(t *testing.T) {
+	r := require.New(t)
+	g := snowflake.WarehouseGrant("test_warehouse")
+	r.Equal(`SHOW GRANTS ON WAREHOUSE "test_warehouse"`, g.Show())
+}